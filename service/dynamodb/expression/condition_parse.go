@@ -0,0 +1,256 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// FilterBuilder represents the FilterExpression clause of a Query or Scan.
+// DynamoDB parses FilterExpression with exactly the same grammar as
+// ConditionExpression, so FilterBuilder is simply a named alias of
+// ConditionBuilder rather than a distinct builder type.
+type FilterBuilder = ConditionBuilder
+
+// ParseCondition reconstructs the ConditionBuilder that produced the
+// given ConditionExpression string, resolving every #name and :value
+// placeholder against the supplied ExpressionAttributeNames and
+// ExpressionAttributeValues.
+func ParseCondition(expr string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (ConditionBuilder, error) {
+	p, err := newExprParser(expr, names, values)
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+	cond, err := p.parseCondition()
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+	if p.peek().kind != tokEOF {
+		return ConditionBuilder{}, fmt.Errorf("parse condition expression error: unexpected trailing token %q", p.peek().lit)
+	}
+	return cond, nil
+}
+
+// ParseFilter reconstructs a FilterBuilder from a FilterExpression string.
+// Since FilterExpression and ConditionExpression share a grammar, this is
+// a thin, self-documenting wrapper around ParseCondition.
+func ParseFilter(expr string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (FilterBuilder, error) {
+	return ParseCondition(expr, names, values)
+}
+
+// parseCondition parses the full condition grammar shared by
+// ConditionExpression and FilterExpression, from lowest to highest
+// precedence:
+//
+//     condition ::= andTerm ("OR" andTerm)*
+//     andTerm   ::= notTerm ("AND" notTerm)*
+//     notTerm   ::= "NOT" notTerm | atom
+//     atom      ::= "(" condition ")" | function | comparison
+func (p *exprParser) parseCondition() (ConditionBuilder, error) {
+	left, err := p.parseAndTerm()
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+	for p.peek().kind == tokKeyword && p.peek().lit == "OR" {
+		p.next()
+		right, err := p.parseAndTerm()
+		if err != nil {
+			return ConditionBuilder{}, err
+		}
+		left = Or(left, right)
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAndTerm() (ConditionBuilder, error) {
+	left, err := p.parseNotTerm()
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+	for p.peek().kind == tokKeyword && p.peek().lit == "AND" {
+		p.next()
+		right, err := p.parseNotTerm()
+		if err != nil {
+			return ConditionBuilder{}, err
+		}
+		left = And(left, right)
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNotTerm() (ConditionBuilder, error) {
+	if p.peek().kind == tokKeyword && p.peek().lit == "NOT" {
+		p.next()
+		inner, err := p.parseNotTerm()
+		if err != nil {
+			return ConditionBuilder{}, err
+		}
+		return Not(inner), nil
+	}
+	return p.parseConditionAtom()
+}
+
+func (p *exprParser) parseConditionAtom() (ConditionBuilder, error) {
+	if p.peek().kind == tokLParen {
+		p.next()
+		cond, err := p.parseCondition()
+		if err != nil {
+			return ConditionBuilder{}, err
+		}
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return ConditionBuilder{}, err
+		}
+		return cond, nil
+	}
+
+	if p.peek().kind == tokIdent && p.peekAt(1).kind == tokLParen && isConditionFunction(p.peek().lit) {
+		return p.parseConditionFunction()
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+	return p.parseComparison(left)
+}
+
+func isConditionFunction(name string) bool {
+	switch strings.ToLower(name) {
+	case "attribute_exists", "attribute_not_exists", "attribute_type", "begins_with", "contains":
+		return true
+	}
+	return false
+}
+
+// parseConditionFunction parses the boolean-valued functions
+// attribute_exists, attribute_not_exists, attribute_type, begins_with,
+// and contains. The caller has already confirmed, via
+// isConditionFunction, that the upcoming identifier is one of these.
+func (p *exprParser) parseConditionFunction() (ConditionBuilder, error) {
+	name := p.next()
+	if _, err := p.expect(tokLParen, "("); err != nil {
+		return ConditionBuilder{}, err
+	}
+	path, err := p.parsePath()
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+
+	switch strings.ToLower(name.lit) {
+	case "attribute_exists":
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return ConditionBuilder{}, err
+		}
+		return AttributeExists(path), nil
+	case "attribute_not_exists":
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return ConditionBuilder{}, err
+		}
+		return AttributeNotExists(path), nil
+	}
+
+	if _, err := p.expect(tokComma, ","); err != nil {
+		return ConditionBuilder{}, err
+	}
+	operand, err := p.parseOperand()
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+	if _, err := p.expect(tokRParen, ")"); err != nil {
+		return ConditionBuilder{}, err
+	}
+
+	// attribute_type, begins_with, and contains all take a literal value
+	// as their second argument, not a generic operand, so unwrap it down
+	// to the ValueBuilder (and then the string) each one requires.
+	fname := strings.ToLower(name.lit)
+	if fname != "attribute_type" && fname != "begins_with" && fname != "contains" {
+		return ConditionBuilder{}, fmt.Errorf("parse condition expression error: unsupported function %q", name.lit)
+	}
+	value, err := operandToValue(fname, operand)
+	if err != nil {
+		return ConditionBuilder{}, err
+	}
+	arg, ok := value.value.(string)
+	if !ok {
+		return ConditionBuilder{}, fmt.Errorf("parse condition expression error: %s argument must be a string, got %T", fname, value.value)
+	}
+
+	switch fname {
+	case "attribute_type":
+		return AttributeType(path, DynamoDBAttributeType(arg)), nil
+	case "begins_with":
+		return BeginsWith(path, arg), nil
+	default:
+		return Contains(path, arg), nil
+	}
+}
+
+// parseComparison parses the comparators, BETWEEN, and IN forms that
+// follow a left-hand operand in a comparison condition.
+func (p *exprParser) parseComparison(left OperandBuilder) (ConditionBuilder, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokOperator:
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return ConditionBuilder{}, err
+		}
+		switch t.lit {
+		case "=":
+			return Equal(left, right), nil
+		case "<>":
+			return NotEqual(left, right), nil
+		case "<":
+			return LessThan(left, right), nil
+		case "<=":
+			return LessThanEqual(left, right), nil
+		case ">":
+			return GreaterThan(left, right), nil
+		case ">=":
+			return GreaterThanEqual(left, right), nil
+		}
+		return ConditionBuilder{}, fmt.Errorf("parse condition expression error: unsupported comparator %q", t.lit)
+
+	case t.kind == tokKeyword && t.lit == "BETWEEN":
+		p.next()
+		lower, err := p.parseOperand()
+		if err != nil {
+			return ConditionBuilder{}, err
+		}
+		if _, err := p.expect(tokKeyword, "AND"); err != nil {
+			return ConditionBuilder{}, err
+		}
+		upper, err := p.parseOperand()
+		if err != nil {
+			return ConditionBuilder{}, err
+		}
+		return Between(left, lower, upper), nil
+
+	case t.kind == tokKeyword && t.lit == "IN":
+		p.next()
+		if _, err := p.expect(tokLParen, "("); err != nil {
+			return ConditionBuilder{}, err
+		}
+		var rest []OperandBuilder
+		for {
+			v, err := p.parseOperand()
+			if err != nil {
+				return ConditionBuilder{}, err
+			}
+			rest = append(rest, v)
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return ConditionBuilder{}, err
+		}
+		return In(left, rest[0], rest[1:]...), nil
+	}
+
+	return ConditionBuilder{}, fmt.Errorf("parse condition expression error: expected comparator, BETWEEN, or IN, got %q", t.lit)
+}
@@ -0,0 +1,87 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+func mustAV(t *testing.T, v interface{}) *dynamodb.AttributeValue {
+	t.Helper()
+	av, err := dynamodbattribute.Marshal(v)
+	if err != nil {
+		t.Fatalf("dynamodbattribute.Marshal(%v) returned error: %v", v, err)
+	}
+	return av
+}
+
+// TestParseConditionRoundTrip exercises a condition that touches the
+// functions attribute_exists, begins_with, and contains, a comparator, and
+// the AND/OR/NOT combinators, and checks the result
+// against the same tree built directly with the package's exported
+// condition builders.
+func TestParseConditionRoundTrip(t *testing.T) {
+	expr := "attribute_exists(a) AND (begins_with(b, :p) OR contains(c, :s)) AND NOT d = :v"
+	values := map[string]*dynamodb.AttributeValue{
+		":p": mustAV(t, "pre"),
+		":s": mustAV(t, "sub"),
+		":v": mustAV(t, 5),
+	}
+
+	got, err := ParseCondition(expr, nil, values)
+	if err != nil {
+		t.Fatalf("ParseCondition(%q) returned error: %v", expr, err)
+	}
+
+	want := And(
+		And(
+			AttributeExists(Name("a")),
+			Or(BeginsWith(Name("b"), "pre"), Contains(Name("c"), "sub")),
+		),
+		Not(Equal(Name("d"), Value(5))),
+	)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCondition(%q) = %#v, want %#v", expr, got, want)
+	}
+}
+
+// TestParseConditionBetweenAndIn covers the BETWEEN and IN comparison
+// forms, which parseComparison handles separately from the single-operand
+// comparators.
+func TestParseConditionBetweenAndIn(t *testing.T) {
+	expr := "a BETWEEN :lo AND :hi AND b IN (:x, :y)"
+	values := map[string]*dynamodb.AttributeValue{
+		":lo": mustAV(t, 1),
+		":hi": mustAV(t, 10),
+		":x":  mustAV(t, "foo"),
+		":y":  mustAV(t, "bar"),
+	}
+
+	got, err := ParseCondition(expr, nil, values)
+	if err != nil {
+		t.Fatalf("ParseCondition(%q) returned error: %v", expr, err)
+	}
+
+	want := And(
+		Between(Name("a"), Value(1), Value(10)),
+		In(Name("b"), Value("foo"), Value("bar")),
+	)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCondition(%q) = %#v, want %#v", expr, got, want)
+	}
+}
+
+// TestParseConditionFunctionArgumentMustBeLiteral confirms that
+// attribute_type, begins_with, and contains reject a non-literal second
+// argument instead of panicking on the type assertion in
+// parseConditionFunction.
+func TestParseConditionFunctionArgumentMustBeLiteral(t *testing.T) {
+	expr := "begins_with(a, b)"
+	if _, err := ParseCondition(expr, nil, nil); err == nil {
+		t.Fatal("ParseCondition with a path as begins_with's second argument should return an error")
+	}
+}
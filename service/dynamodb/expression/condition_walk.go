@@ -0,0 +1,97 @@
+package expression
+
+// ConditionMode identifies the kind of node inside a ConditionBuilder
+// tree: a leaf built from operands (Equal, Between, AttributeExists, and
+// so on), or an AND/OR/NOT combinator joining other ConditionBuilders.
+type ConditionMode int
+
+// The ConditionMode values WalkCondition's callback will see.
+const (
+	ConditionModeValue ConditionMode = iota
+	ConditionModeAnd
+	ConditionModeOr
+	ConditionModeNot
+)
+
+// ConditionOp is a stable, exported view of one node in a
+// ConditionBuilder tree for use with WalkCondition. Operands is populated
+// for leaf nodes; Conditions holds the joined sub-trees for AND/OR/NOT
+// combinator nodes.
+type ConditionOp struct {
+	Mode       ConditionMode
+	Operands   []OperandBuilder
+	Conditions []ConditionBuilder
+}
+
+func (cb ConditionBuilder) toConditionOp() ConditionOp {
+	switch cb.mode {
+	case andCond:
+		return ConditionOp{Mode: ConditionModeAnd, Conditions: cb.conditionList}
+	case orCond:
+		return ConditionOp{Mode: ConditionModeOr, Conditions: cb.conditionList}
+	case notCond:
+		return ConditionOp{Mode: ConditionModeNot, Conditions: cb.conditionList}
+	default:
+		return ConditionOp{Mode: ConditionModeValue, Operands: cb.operandList}
+	}
+}
+
+func (op ConditionOp) toConditionBuilder(mode conditionMode) ConditionBuilder {
+	switch op.Mode {
+	case ConditionModeAnd, ConditionModeOr, ConditionModeNot:
+		return ConditionBuilder{conditionList: op.Conditions, mode: mode}
+	default:
+		return ConditionBuilder{operandList: op.Operands, mode: mode}
+	}
+}
+
+// WalkCondition recursively descends a ConditionBuilder tree, invoking fn
+// on every node (leaves first, then each AND/OR/NOT combinator over the
+// already-walked sub-trees) and replacing the node with fn's return
+// value. A common use is rewriting every comparison's operands, e.g. to
+// rename an attribute path wherever it's compared:
+//
+//     renamed := expression.WalkCondition(cond, func(op expression.ConditionOp) expression.ConditionOp {
+//         for i, operand := range op.Operands {
+//             if nb, ok := operand.(expression.NameBuilder); ok && nb == oldName {
+//                 op.Operands[i] = newName
+//             }
+//         }
+//         return op
+//     })
+func WalkCondition(cb ConditionBuilder, fn func(op ConditionOp) ConditionOp) ConditionBuilder {
+	mode := cb.mode
+	op := cb.toConditionOp()
+
+	if op.Mode != ConditionModeValue {
+		walkedChildren := make([]ConditionBuilder, len(op.Conditions))
+		for i, child := range op.Conditions {
+			walkedChildren[i] = WalkCondition(child, fn)
+		}
+		op.Conditions = walkedChildren
+	}
+
+	return fn(op).toConditionBuilder(mode)
+}
+
+// ProjectionOp is a stable, exported view of a ProjectionBuilder's
+// attribute paths for use with WalkProjection.
+type ProjectionOp struct {
+	Names []NameBuilder
+}
+
+// WalkProjection invokes fn with every path in pb and rebuilds a
+// ProjectionBuilder from fn's return value. A common use is renaming or
+// filtering the set of attributes a Query or Scan projects:
+//
+//     trimmed := expression.WalkProjection(proj, func(op expression.ProjectionOp) expression.ProjectionOp {
+//         op.Names = append(op.Names[:0], op.Names[dropFirstN:]...)
+//         return op
+//     })
+func WalkProjection(pb ProjectionBuilder, fn func(op ProjectionOp) ProjectionOp) ProjectionBuilder {
+	result := fn(ProjectionOp{Names: pb.names})
+	if len(result.Names) == 0 {
+		return ProjectionBuilder{}
+	}
+	return NamesList(result.Names[0], result.Names[1:]...)
+}
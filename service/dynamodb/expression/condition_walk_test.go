@@ -0,0 +1,61 @@
+package expression
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalkConditionRenamesEveryOperand(t *testing.T) {
+	cond := And(
+		Equal(Name("old.a"), Value(1)),
+		Or(AttributeExists(Name("old.b")), Not(Equal(Name("old.c"), Value(2)))),
+	)
+
+	renamed := WalkCondition(cond, func(op ConditionOp) ConditionOp {
+		for i, operand := range op.Operands {
+			nb, ok := operand.(NameBuilder)
+			if !ok || !strings.HasPrefix(nb.name, "old.") {
+				continue
+			}
+			op.Operands[i] = Name("new" + strings.TrimPrefix(nb.name, "old"))
+		}
+		return op
+	})
+
+	want := And(
+		Equal(Name("new.a"), Value(1)),
+		Or(AttributeExists(Name("new.b")), Not(Equal(Name("new.c"), Value(2)))),
+	)
+
+	if !reflect.DeepEqual(renamed, want) {
+		t.Errorf("WalkCondition rename = %#v, want %#v", renamed, want)
+	}
+}
+
+func TestWalkProjectionDropsFirstName(t *testing.T) {
+	proj := NamesList(Name("a"), Name("b"), Name("c"))
+
+	trimmed := WalkProjection(proj, func(op ProjectionOp) ProjectionOp {
+		op.Names = op.Names[1:]
+		return op
+	})
+
+	want := NamesList(Name("b"), Name("c"))
+	if !reflect.DeepEqual(trimmed, want) {
+		t.Errorf("WalkProjection drop-first = %#v, want %#v", trimmed, want)
+	}
+}
+
+func TestWalkProjectionToEmptyReturnsZeroValue(t *testing.T) {
+	proj := NamesList(Name("a"))
+
+	emptied := WalkProjection(proj, func(op ProjectionOp) ProjectionOp {
+		op.Names = nil
+		return op
+	})
+
+	if !reflect.DeepEqual(emptied, ProjectionBuilder{}) {
+		t.Errorf("WalkProjection to empty = %#v, want zero value", emptied)
+	}
+}
@@ -0,0 +1,149 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// ParseKeyCondition reconstructs the KeyConditionBuilder that produced
+// the given KeyConditionExpression string. Unlike ParseCondition, the
+// grammar here is restricted to what DynamoDB allows in a key condition:
+// exactly one partition key equality, optionally AND'd with one sort key
+// comparison, BETWEEN range, or begins_with() call.
+func ParseKeyCondition(expr string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (KeyConditionBuilder, error) {
+	p, err := newExprParser(expr, names, values)
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	kc, err := p.parseKeyCondition()
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	if p.peek().kind != tokEOF {
+		return KeyConditionBuilder{}, fmt.Errorf("parse key condition expression error: unexpected trailing token %q", p.peek().lit)
+	}
+	return kc, nil
+}
+
+func (p *exprParser) parseKeyCondition() (KeyConditionBuilder, error) {
+	partitionKey, err := p.parseKeyName()
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	if _, err := p.expect(tokOperator, "="); err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	value, err := p.parseKeyValue("key condition partition key comparison")
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	kc := KeyEqual(partitionKey, value)
+
+	if p.peek().kind != tokKeyword || p.peek().lit != "AND" {
+		return kc, nil
+	}
+	p.next()
+
+	sortKey, err := p.parseSortKeyCondition()
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	return KeyAnd(kc, sortKey), nil
+}
+
+// parseKeyName parses a single attribute path the same way parsePath
+// does, then wraps it in a KeyBuilder — the key-condition operators
+// (KeyEqual, KeyBetween, ...) take a KeyBuilder, not the generic
+// NameBuilder a ConditionExpression path resolves to.
+func (p *exprParser) parseKeyName() (KeyBuilder, error) {
+	name, err := p.parsePath()
+	if err != nil {
+		return KeyBuilder{}, err
+	}
+	return Key(name.name), nil
+}
+
+// parseKeyValue parses a single operand and requires it to be a literal
+// value, since every Key* comparator takes a ValueBuilder rather than a
+// generic OperandBuilder.
+func (p *exprParser) parseKeyValue(context string) (ValueBuilder, error) {
+	operand, err := p.parseOperand()
+	if err != nil {
+		return ValueBuilder{}, err
+	}
+	return operandToValue(context, operand)
+}
+
+// parseSortKeyCondition parses the single sort key term DynamoDB allows
+// after the mandatory partition key equality: a comparator, a BETWEEN
+// range, or a begins_with() call.
+func (p *exprParser) parseSortKeyCondition() (KeyConditionBuilder, error) {
+	if p.peek().kind == tokIdent && strings.ToLower(p.peek().lit) == "begins_with" && p.peekAt(1).kind == tokLParen {
+		p.next()
+		p.next()
+		key, err := p.parseKeyName()
+		if err != nil {
+			return KeyConditionBuilder{}, err
+		}
+		if _, err := p.expect(tokComma, ","); err != nil {
+			return KeyConditionBuilder{}, err
+		}
+		prefixValue, err := p.parseKeyValue("key condition begins_with")
+		if err != nil {
+			return KeyConditionBuilder{}, err
+		}
+		prefix, ok := prefixValue.value.(string)
+		if !ok {
+			return KeyConditionBuilder{}, fmt.Errorf("parse key condition expression error: begins_with argument must be a string, got %T", prefixValue.value)
+		}
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return KeyConditionBuilder{}, err
+		}
+		return KeyBeginsWith(key, prefix), nil
+	}
+
+	key, err := p.parseKeyName()
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+
+	if p.peek().kind == tokKeyword && p.peek().lit == "BETWEEN" {
+		p.next()
+		lower, err := p.parseKeyValue("key condition BETWEEN lower bound")
+		if err != nil {
+			return KeyConditionBuilder{}, err
+		}
+		if _, err := p.expect(tokKeyword, "AND"); err != nil {
+			return KeyConditionBuilder{}, err
+		}
+		upper, err := p.parseKeyValue("key condition BETWEEN upper bound")
+		if err != nil {
+			return KeyConditionBuilder{}, err
+		}
+		return KeyBetween(key, lower, upper), nil
+	}
+
+	op, err := p.expect(tokOperator, "")
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	right, err := p.parseKeyValue("key condition comparison")
+	if err != nil {
+		return KeyConditionBuilder{}, err
+	}
+	switch op.lit {
+	case "=":
+		return KeyEqual(key, right), nil
+	case "<":
+		return KeyLessThan(key, right), nil
+	case "<=":
+		return KeyLessThanEqual(key, right), nil
+	case ">":
+		return KeyGreaterThan(key, right), nil
+	case ">=":
+		return KeyGreaterThanEqual(key, right), nil
+	}
+	return KeyConditionBuilder{}, fmt.Errorf("parse key condition expression error: unsupported key comparator %q", op.lit)
+}
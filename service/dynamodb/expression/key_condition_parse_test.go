@@ -0,0 +1,96 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+func TestParseKeyConditionEqualityOnly(t *testing.T) {
+	expr := "id = :id"
+	values := map[string]*dynamodb.AttributeValue{
+		":id": mustAV(t, "abc"),
+	}
+
+	got, err := ParseKeyCondition(expr, nil, values)
+	if err != nil {
+		t.Fatalf("ParseKeyCondition(%q) returned error: %v", expr, err)
+	}
+
+	want := KeyEqual(Key("id"), Value("abc"))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseKeyCondition(%q) = %#v, want %#v", expr, got, want)
+	}
+}
+
+func TestParseKeyConditionWithSortKeyBetween(t *testing.T) {
+	expr := "id = :id AND sort BETWEEN :lo AND :hi"
+	values := map[string]*dynamodb.AttributeValue{
+		":id": mustAV(t, "abc"),
+		":lo": mustAV(t, 1),
+		":hi": mustAV(t, 10),
+	}
+
+	got, err := ParseKeyCondition(expr, nil, values)
+	if err != nil {
+		t.Fatalf("ParseKeyCondition(%q) returned error: %v", expr, err)
+	}
+
+	want := KeyAnd(
+		KeyEqual(Key("id"), Value("abc")),
+		KeyBetween(Key("sort"), Value(1), Value(10)),
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseKeyCondition(%q) = %#v, want %#v", expr, got, want)
+	}
+}
+
+func TestParseKeyConditionWithSortKeyBeginsWith(t *testing.T) {
+	expr := "id = :id AND begins_with(sort, :p)"
+	values := map[string]*dynamodb.AttributeValue{
+		":id": mustAV(t, "abc"),
+		":p":  mustAV(t, "pre"),
+	}
+
+	got, err := ParseKeyCondition(expr, nil, values)
+	if err != nil {
+		t.Fatalf("ParseKeyCondition(%q) returned error: %v", expr, err)
+	}
+
+	want := KeyAnd(
+		KeyEqual(Key("id"), Value("abc")),
+		KeyBeginsWith(Key("sort"), "pre"),
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseKeyCondition(%q) = %#v, want %#v", expr, got, want)
+	}
+}
+
+func TestParseKeyConditionSortKeyComparator(t *testing.T) {
+	expr := "id = :id AND sort >= :lo"
+	values := map[string]*dynamodb.AttributeValue{
+		":id": mustAV(t, "abc"),
+		":lo": mustAV(t, 5),
+	}
+
+	got, err := ParseKeyCondition(expr, nil, values)
+	if err != nil {
+		t.Fatalf("ParseKeyCondition(%q) returned error: %v", expr, err)
+	}
+
+	want := KeyAnd(
+		KeyEqual(Key("id"), Value("abc")),
+		KeyGreaterThanEqual(Key("sort"), Value(5)),
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseKeyCondition(%q) = %#v, want %#v", expr, got, want)
+	}
+}
+
+func TestParseKeyConditionRejectsNonLiteralValue(t *testing.T) {
+	expr := "id = sortAttr"
+	if _, err := ParseKeyCondition(expr, nil, nil); err == nil {
+		t.Fatal("ParseKeyCondition comparing a key to a path (not a literal) should return an error")
+	}
+}
@@ -0,0 +1,426 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// tokenKind identifies the lexical category of a token produced by
+// tokenize. All of the parsers in this package (update, condition, key
+// condition, projection) share the same token stream, since DynamoDB
+// expression strings share the same lexical rules even though each
+// expression type layers its own grammar on top.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNamePlaceholder
+	tokValuePlaceholder
+	tokNumber
+	tokString
+	tokKeyword
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokDot
+	tokComma
+	tokOperator
+)
+
+type token struct {
+	kind tokenKind
+	lit  string
+}
+
+// updateKeywords and conditionKeywords are the reserved words that
+// tokenize refuses to hand back as plain identifiers. Everything else
+// (including function names like if_not_exists or size) is a tokIdent;
+// the parsers disambiguate those by looking for a following "(".
+var updateKeywords = map[string]bool{
+	"SET":    true,
+	"REMOVE": true,
+	"ADD":    true,
+	"DELETE": true,
+}
+
+var conditionKeywords = map[string]bool{
+	"AND":     true,
+	"OR":      true,
+	"NOT":     true,
+	"BETWEEN": true,
+	"IN":      true,
+}
+
+// tokenize breaks an update, condition, key condition, or projection
+// expression string into a flat list of tokens. Keeping the lexer in one
+// place means quoting, placeholders, and nested path syntax only need to
+// be handled once for all of the expression parsers in this package.
+func tokenize(expr string) ([]token, error) {
+	var toks []token
+	runes := []rune(expr)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			toks = append(toks, token{tokLParen, "("})
+			i++
+		case r == ')':
+			toks = append(toks, token{tokRParen, ")"})
+			i++
+		case r == '[':
+			toks = append(toks, token{tokLBracket, "["})
+			i++
+		case r == ']':
+			toks = append(toks, token{tokRBracket, "]"})
+			i++
+		case r == '.':
+			toks = append(toks, token{tokDot, "."})
+			i++
+		case r == ',':
+			toks = append(toks, token{tokComma, ","})
+			i++
+		case r == '"':
+			lit, n, err := scanQuoted(runes[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{tokString, lit})
+			i += n
+		case r == '#':
+			j := i + 1
+			for j < len(runes) && isIdentRune(runes[j]) {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("tokenize expression error: empty name placeholder at position %d", i)
+			}
+			toks = append(toks, token{tokNamePlaceholder, string(runes[i:j])})
+			i = j
+		case r == ':':
+			j := i + 1
+			for j < len(runes) && isIdentRune(runes[j]) {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("tokenize expression error: empty value placeholder at position %d", i)
+			}
+			toks = append(toks, token{tokValuePlaceholder, string(runes[i:j])})
+			i = j
+		case r == '=':
+			toks = append(toks, token{tokOperator, "="})
+			i++
+		case r == '<':
+			if i+1 < len(runes) && (runes[i+1] == '=' || runes[i+1] == '>') {
+				toks = append(toks, token{tokOperator, string(runes[i : i+2])})
+				i += 2
+			} else {
+				toks = append(toks, token{tokOperator, "<"})
+				i++
+			}
+		case r == '>':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				toks = append(toks, token{tokOperator, ">="})
+				i += 2
+			} else {
+				toks = append(toks, token{tokOperator, ">"})
+				i++
+			}
+		case r == '+' || r == '-':
+			toks = append(toks, token{tokOperator, string(r)})
+			i++
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			toks = append(toks, token{tokNumber, string(runes[i:j])})
+			i = j
+		case isIdentStartRune(r):
+			j := i
+			for j < len(runes) && isIdentRune(runes[j]) {
+				j++
+			}
+			lit := string(runes[i:j])
+			upper := strings.ToUpper(lit)
+			if updateKeywords[upper] || conditionKeywords[upper] {
+				toks = append(toks, token{tokKeyword, upper})
+			} else {
+				toks = append(toks, token{tokIdent, lit})
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("tokenize expression error: unexpected character %q at position %d", r, i)
+		}
+	}
+	return toks, nil
+}
+
+func isIdentStartRune(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// scanQuoted reads a double-quoted path segment, used for attribute names
+// that collide with reserved words or contain characters that aren't
+// valid in a bare identifier (spaces, dots meant literally, and so on).
+// It returns the unescaped contents and the number of runes consumed,
+// including both quotes.
+func scanQuoted(runes []rune) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 >= len(runes) {
+				return "", 0, fmt.Errorf("tokenize expression error: unterminated escape in quoted segment")
+			}
+			b.WriteRune(runes[i+1])
+			i++
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+	return "", 0, fmt.Errorf("tokenize expression error: unterminated quoted segment")
+}
+
+// exprParser walks the token stream produced by tokenize, resolving
+// #name and :value placeholders against the ExpressionAttributeNames and
+// ExpressionAttributeValues maps along the way. update.go,
+// condition_parse.go, key_condition_parse.go, and projection_parse.go
+// each layer their own clause grammar on top of the shared helpers
+// defined here.
+type exprParser struct {
+	toks   []token
+	pos    int
+	names  map[string]*string
+	values map[string]*dynamodb.AttributeValue
+}
+
+func newExprParser(expr string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (*exprParser, error) {
+	toks, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &exprParser{toks: toks, names: names, values: values}, nil
+}
+
+func (p *exprParser) peek() token {
+	if p.pos >= len(p.toks) {
+		return token{kind: tokEOF}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *exprParser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.toks) {
+		return token{kind: tokEOF}
+	}
+	return p.toks[p.pos+offset]
+}
+
+func (p *exprParser) next() token {
+	t := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return t
+}
+
+// expect consumes the next token, verifying its kind and (if lit is
+// non-empty) its literal value. Literal comparisons are case-insensitive
+// so that keywords like "and"/"AND" both parse.
+func (p *exprParser) expect(kind tokenKind, lit string) (token, error) {
+	t := p.next()
+	if t.kind != kind || (lit != "" && !strings.EqualFold(t.lit, lit)) {
+		want := lit
+		if want == "" {
+			want = fmt.Sprintf("token of kind %d", kind)
+		}
+		return token{}, fmt.Errorf("parse expression error: expected %q, got %q", want, t.lit)
+	}
+	return t, nil
+}
+
+// parsePath parses a single attribute path such as "#n.a1[0]" or
+// "root.a" into a NameBuilder, substituting any #name placeholders along
+// the way and re-joining the "."-delimited hops into the dotted,
+// bracket-indexed string Name(string) expects.
+//
+// Note: NameBuilder's BuildOperand always splits its name string on
+// every ".", so a #name placeholder that resolves to a raw attribute
+// name containing a literal "." cannot be told apart from a path
+// separator once it's folded back into that string — this is a limit of
+// NameBuilder itself, not something parsePath can work around without a
+// NameBuilder that tracks segments directly.
+func (p *exprParser) parsePath() (NameBuilder, error) {
+	var parts []string
+	for {
+		var part string
+		switch p.peek().kind {
+		case tokNamePlaceholder:
+			t := p.next()
+			name, ok := p.names[t.lit]
+			if !ok || name == nil {
+				return NameBuilder{}, fmt.Errorf("parse expression error: name placeholder %q not found in ExpressionAttributeNames", t.lit)
+			}
+			part = *name
+		case tokIdent, tokString:
+			t := p.next()
+			part = t.lit
+		default:
+			if len(parts) == 0 {
+				return NameBuilder{}, fmt.Errorf("parse expression error: expected attribute path, got %q", p.peek().lit)
+			}
+			return Name(strings.Join(parts, ".")), nil
+		}
+
+		for p.peek().kind == tokLBracket {
+			p.next()
+			idx, err := p.expect(tokNumber, "")
+			if err != nil {
+				return NameBuilder{}, err
+			}
+			if _, err := p.expect(tokRBracket, "]"); err != nil {
+				return NameBuilder{}, err
+			}
+			if _, err := strconv.Atoi(idx.lit); err != nil {
+				return NameBuilder{}, fmt.Errorf("parse expression error: invalid index %q", idx.lit)
+			}
+			part += "[" + idx.lit + "]"
+		}
+		parts = append(parts, part)
+
+		if p.peek().kind != tokDot {
+			return Name(strings.Join(parts, ".")), nil
+		}
+		p.next()
+	}
+}
+
+// parseOperand parses a single operand: a value placeholder, an
+// attribute path, or one of the non-boolean functions (if_not_exists,
+// list_append, size).
+func (p *exprParser) parseOperand() (OperandBuilder, error) {
+	switch t := p.peek(); t.kind {
+	case tokValuePlaceholder:
+		p.next()
+		av, ok := p.values[t.lit]
+		if !ok || av == nil {
+			return nil, fmt.Errorf("parse expression error: value placeholder %q not found in ExpressionAttributeValues", t.lit)
+		}
+		var v interface{}
+		if err := dynamodbattribute.Unmarshal(av, &v); err != nil {
+			return nil, fmt.Errorf("parse expression error: unmarshal value placeholder %q: %v", t.lit, err)
+		}
+		// A top-level N value that is a whole number comes back as an int,
+		// so that Value(5) round-trips as Value(5) rather than
+		// Value(float64(5)); any other number stays a float64.
+		if av.N != nil {
+			if n, err := strconv.Atoi(*av.N); err == nil {
+				v = n
+			}
+		}
+		return Value(v), nil
+	case tokIdent:
+		if p.peekAt(1).kind == tokLParen {
+			return p.parseFunctionCall()
+		}
+		return p.parsePath()
+	case tokNamePlaceholder, tokString:
+		return p.parsePath()
+	default:
+		return nil, fmt.Errorf("parse expression error: unexpected token %q while parsing operand", t.lit)
+	}
+}
+
+// parseFunctionCall parses the operand-valued functions: if_not_exists,
+// list_append, and size. The boolean-valued functions (attribute_exists,
+// attribute_not_exists, attribute_type, begins_with, contains) are parsed
+// by condition_parse.go instead, since they produce a ConditionBuilder
+// rather than an OperandBuilder.
+func (p *exprParser) parseFunctionCall() (OperandBuilder, error) {
+	name := p.next()
+	if _, err := p.expect(tokLParen, "("); err != nil {
+		return nil, err
+	}
+	args, err := p.parseArgList()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokRParen, ")"); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(name.lit) {
+	case "if_not_exists":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("parse expression error: if_not_exists takes 2 arguments, got %d", len(args))
+		}
+		nb, ok := args[0].(NameBuilder)
+		if !ok {
+			return nil, fmt.Errorf("parse expression error: if_not_exists first argument must be a path")
+		}
+		return IfNotExists(nb, args[1]), nil
+	case "list_append":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("parse expression error: list_append takes 2 arguments, got %d", len(args))
+		}
+		return ListAppend(args[0], args[1]), nil
+	case "size":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("parse expression error: size takes 1 argument, got %d", len(args))
+		}
+		nb, ok := args[0].(NameBuilder)
+		if !ok {
+			return nil, fmt.Errorf("parse expression error: size argument must be a path")
+		}
+		return nb.Size(), nil
+	default:
+		return nil, fmt.Errorf("parse expression error: unsupported function %q", name.lit)
+	}
+}
+
+func (p *exprParser) parseArgList() ([]OperandBuilder, error) {
+	var args []OperandBuilder
+	if p.peek().kind == tokRParen {
+		return args, nil
+	}
+	for {
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.next()
+	}
+	return args, nil
+}
+
+// operandToValue asserts that op is a ValueBuilder, as required by APIs
+// — key conditions, attribute_type, begins_with, contains — that take a
+// literal value rather than a generic operand (a path, if_not_exists(),
+// and so on don't make sense there).
+func operandToValue(context string, op OperandBuilder) (ValueBuilder, error) {
+	vb, ok := op.(ValueBuilder)
+	if !ok {
+		return ValueBuilder{}, fmt.Errorf("parse expression error: %s requires a value, got %T", context, op)
+	}
+	return vb, nil
+}
@@ -0,0 +1,96 @@
+package expression
+
+import "github.com/aws/aws-sdk-go/service/dynamodb"
+
+// ParseQuery reconstructs the KeyConditionBuilder, FilterBuilder (if a
+// FilterExpression is present), and ProjectionBuilder (if a
+// ProjectionExpression is present) that produced a QueryInput's
+// expressions. The filter and projection return values are nil when the
+// corresponding expression is absent from input.
+func ParseQuery(input *dynamodb.QueryInput) (KeyConditionBuilder, *FilterBuilder, *ProjectionBuilder, error) {
+	if input == nil || input.KeyConditionExpression == nil {
+		return KeyConditionBuilder{}, nil, nil, newUnsetParameterError("ParseQuery", "QueryInput")
+	}
+
+	kc, err := ParseKeyCondition(*input.KeyConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return KeyConditionBuilder{}, nil, nil, err
+	}
+
+	filter, err := parseOptionalFilter(input.FilterExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return KeyConditionBuilder{}, nil, nil, err
+	}
+
+	proj, err := parseOptionalProjection(input.ProjectionExpression, input.ExpressionAttributeNames)
+	if err != nil {
+		return KeyConditionBuilder{}, nil, nil, err
+	}
+
+	return kc, filter, proj, nil
+}
+
+// ParseScan reconstructs the FilterBuilder (if a FilterExpression is
+// present) and ProjectionBuilder (if a ProjectionExpression is present)
+// that produced a ScanInput's expressions.
+func ParseScan(input *dynamodb.ScanInput) (*FilterBuilder, *ProjectionBuilder, error) {
+	if input == nil {
+		return nil, nil, newUnsetParameterError("ParseScan", "ScanInput")
+	}
+
+	filter, err := parseOptionalFilter(input.FilterExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proj, err := parseOptionalProjection(input.ProjectionExpression, input.ExpressionAttributeNames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return filter, proj, nil
+}
+
+// ParsePut reconstructs the ConditionBuilder that produced a
+// PutItemInput's ConditionExpression, or nil if none is present.
+func ParsePut(input *dynamodb.PutItemInput) (*ConditionBuilder, error) {
+	if input == nil {
+		return nil, newUnsetParameterError("ParsePut", "PutItemInput")
+	}
+	return parseOptionalCondition(input.ConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+}
+
+// ParseDelete reconstructs the ConditionBuilder that produced a
+// DeleteItemInput's ConditionExpression, or nil if none is present.
+func ParseDelete(input *dynamodb.DeleteItemInput) (*ConditionBuilder, error) {
+	if input == nil {
+		return nil, newUnsetParameterError("ParseDelete", "DeleteItemInput")
+	}
+	return parseOptionalCondition(input.ConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+}
+
+func parseOptionalCondition(expr *string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (*ConditionBuilder, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	cond, err := ParseCondition(*expr, names, values)
+	if err != nil {
+		return nil, err
+	}
+	return &cond, nil
+}
+
+func parseOptionalFilter(expr *string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (*FilterBuilder, error) {
+	return parseOptionalCondition(expr, names, values)
+}
+
+func parseOptionalProjection(expr *string, names map[string]*string) (*ProjectionBuilder, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	proj, err := ParseProjection(*expr, names)
+	if err != nil {
+		return nil, err
+	}
+	return &proj, nil
+}
@@ -0,0 +1,195 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		name string
+		expr string
+		want []token
+	}{
+		{
+			name: "simple path",
+			expr: "a.b",
+			want: []token{
+				{tokIdent, "a"},
+				{tokDot, "."},
+				{tokIdent, "b"},
+			},
+		},
+		{
+			name: "placeholders and index",
+			expr: "#n[0] = :v",
+			want: []token{
+				{tokNamePlaceholder, "#n"},
+				{tokLBracket, "["},
+				{tokNumber, "0"},
+				{tokRBracket, "]"},
+				{tokOperator, "="},
+				{tokValuePlaceholder, ":v"},
+			},
+		},
+		{
+			name: "comparators",
+			expr: "<= <> >=",
+			want: []token{
+				{tokOperator, "<="},
+				{tokOperator, "<>"},
+				{tokOperator, ">="},
+			},
+		},
+		{
+			name: "keywords are case-insensitive but normalized to upper",
+			expr: "set REMOVE and",
+			want: []token{
+				{tokKeyword, "SET"},
+				{tokKeyword, "REMOVE"},
+				{tokKeyword, "AND"},
+			},
+		},
+		{
+			name: "quoted segment",
+			expr: `"with a dot."`,
+			want: []token{
+				{tokString, "with a dot."},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := tokenize(c.expr)
+			if err != nil {
+				t.Fatalf("tokenize(%q) returned error: %v", c.expr, err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("tokenize(%q) = %#v, want %#v", c.expr, got, c.want)
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	cases := []struct {
+		name   string
+		expr   string
+		names  map[string]*string
+		want   NameBuilder
+		wantOk bool
+	}{
+		{
+			name:   "bare dotted path with index",
+			expr:   "root.a.a1[0]",
+			want:   Name("root.a.a1[0]"),
+			wantOk: true,
+		},
+		{
+			name: "name placeholder is substituted before rejoining",
+			expr: "a.#n",
+			names: map[string]*string{
+				"#n": strPtr("b"),
+			},
+			want:   Name("a.b"),
+			wantOk: true,
+		},
+		{
+			name:   "unresolved placeholder is an error",
+			expr:   "#missing",
+			wantOk: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p, err := newExprParser(c.expr, c.names, nil)
+			if err != nil {
+				t.Fatalf("newExprParser(%q) returned error: %v", c.expr, err)
+			}
+			nb, err := p.parsePath()
+			if !c.wantOk {
+				if err == nil {
+					t.Fatalf("parsePath(%q) = %#v, want error", c.expr, nb)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePath(%q) returned error: %v", c.expr, err)
+			}
+			if nb != c.want {
+				t.Errorf("parsePath(%q) = %#v, want %#v", c.expr, nb, c.want)
+			}
+		})
+	}
+}
+
+func TestParseUpdateRemove(t *testing.T) {
+	input := &dynamodb.UpdateItemInput{
+		UpdateExpression: strPtr("REMOVE a.b[0], c"),
+	}
+
+	ub, err := ParseUpdate(input)
+	if err != nil {
+		t.Fatalf("ParseUpdate returned error: %v", err)
+	}
+
+	ops, ok := ub.operationList[removeOperation]
+	if !ok || len(ops) != 2 {
+		t.Fatalf("ParseUpdate produced %d REMOVE ops, want 2", len(ops))
+	}
+	if got, want := ops[0].name, Name("a.b[0]"); got != want {
+		t.Errorf("ops[0].name = %#v, want %#v", got, want)
+	}
+	if got, want := ops[1].name, Name("c"); got != want {
+		t.Errorf("ops[1].name = %#v, want %#v", got, want)
+	}
+}
+
+// TestParseUpdateFullExample round-trips an UpdateExpression exercising
+// every clause and the arithmetic/function forms SET supports, checking
+// the result against the same UpdateBuilder built directly with the
+// package's exported update builders.
+func TestParseUpdateFullExample(t *testing.T) {
+	input := &dynamodb.UpdateItemInput{
+		UpdateExpression: strPtr("SET a = if_not_exists(a, :v) + b[0], c = list_append(c, :l) REMOVE d[2] ADD e :n DELETE f :s"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":v": mustAV(t, 0),
+			":l": mustAV(t, []string{"x"}),
+			":n": mustAV(t, 1),
+			":s": mustAV(t, "y"),
+		},
+	}
+
+	got, err := ParseUpdate(input)
+	if err != nil {
+		t.Fatalf("ParseUpdate returned error: %v", err)
+	}
+
+	want := UpdateBuilder{}.
+		Set(Name("a"), Plus(IfNotExists(Name("a"), Value(0)), Name("b[0]"))).
+		Set(Name("c"), ListAppend(Name("c"), Value([]interface{}{"x"}))).
+		Remove(Name("d[2]")).
+		Add(Name("e"), Value(1)).
+		Delete(Name("f"), Value("y"))
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseUpdate full example = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseUpdateRejectsUnknownClause(t *testing.T) {
+	input := &dynamodb.UpdateItemInput{
+		UpdateExpression: strPtr("MODIFY a"),
+	}
+	if _, err := ParseUpdate(input); err == nil {
+		t.Fatal("ParseUpdate with an unknown clause keyword should return an error")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,114 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathBuilder incrementally constructs the dotted, bracket-indexed path
+// string that Name(string) expects, one field or list index at a time, so
+// callers don't have to hand-assemble "a.b[0].c" (and its index brackets)
+// themselves. Build() still goes through Name(string), so it inherits
+// NameBuilder's usual behavior of splitting on every "." — a segment
+// containing a literal "." or "[" is not escaped, it is just the same
+// string concatenation Name(string) would do if you wrote it out by hand.
+//
+// Example:
+//
+//     name := expression.Path("root").Field("a").Field("a1").Index(0).Build()
+//     // equivalent to expression.Name("root.a.a1[0]")
+type PathBuilder struct {
+	segments []string
+}
+
+// Path starts a PathBuilder rooted at the top-level attribute root.
+func Path(root string) PathBuilder {
+	return PathBuilder{segments: []string{root}}
+}
+
+// Field appends a nested field access to the path.
+func (pb PathBuilder) Field(name string) PathBuilder {
+	segs := make([]string, len(pb.segments), len(pb.segments)+1)
+	copy(segs, pb.segments)
+	return PathBuilder{segments: append(segs, name)}
+}
+
+// Index appends a list index to the field most recently added by Field
+// (or by Path, for the root field).
+func (pb PathBuilder) Index(i int) PathBuilder {
+	segs := make([]string, len(pb.segments))
+	copy(segs, pb.segments)
+	if len(segs) > 0 {
+		segs[len(segs)-1] = fmt.Sprintf("%s[%d]", segs[len(segs)-1], i)
+	}
+	return PathBuilder{segments: segs}
+}
+
+// Build returns the NameBuilder the accumulated path describes, by
+// joining pb's segments with "." and passing the result to Name(string),
+// the same as if the path had been written out directly.
+func (pb PathBuilder) Build() NameBuilder {
+	return Name(strings.Join(pb.segments, "."))
+}
+
+// validate reports whether pb describes a path that is legal to target
+// with a REMOVE operation: it must name at least one field, and none of
+// its segments may be blank.
+func (pb PathBuilder) validate() error {
+	if len(pb.segments) == 0 {
+		return fmt.Errorf("expression: RemovePath requires at least one field")
+	}
+	for _, seg := range pb.segments {
+		if strings.TrimSpace(seg) == "" {
+			return fmt.Errorf("expression: RemovePath segment %q is not a valid field name", seg)
+		}
+	}
+	return nil
+}
+
+// RemovePath adds a Remove operation for path to ub, after validating
+// that path names at least one field.
+func (ub UpdateBuilder) RemovePath(path PathBuilder) (UpdateBuilder, error) {
+	if err := path.validate(); err != nil {
+		return UpdateBuilder{}, err
+	}
+	return ub.Remove(path.Build()), nil
+}
+
+// RemoveIndex adds a Remove operation that targets a single list index
+// appended to path, e.g. RemoveIndex(Path("a").Field("list"), 2) removes
+// "a.list[2]".
+func (ub UpdateBuilder) RemoveIndex(path PathBuilder, i int) (UpdateBuilder, error) {
+	if i < 0 {
+		return UpdateBuilder{}, fmt.Errorf("expression: RemoveIndex requires a non-negative index, got %d", i)
+	}
+	indexed := path.Index(i)
+	if err := indexed.validate(); err != nil {
+		return UpdateBuilder{}, err
+	}
+	return ub.Remove(indexed.Build()), nil
+}
+
+// RemoveIndexRange adds a Remove operation for every index from lo to hi
+// (inclusive) appended to path. DynamoDB has no native range removal, so
+// this expands to one REMOVE target per index. DynamoDB re-indexes the
+// remaining list elements after each target is removed within the same
+// UpdateExpression, so the targets must be listed highest index first;
+// removing low-to-high would shift later elements into earlier indices
+// before they're removed, deleting the wrong elements for any range
+// longer than one (e.g. removing [2] then [4] low-to-high actually
+// removes original indices 2 and 5, not 2 and 4).
+func (ub UpdateBuilder) RemoveIndexRange(path PathBuilder, lo, hi int) (UpdateBuilder, error) {
+	if hi < lo {
+		return UpdateBuilder{}, fmt.Errorf("expression: RemoveIndexRange requires lo <= hi, got lo=%d hi=%d", lo, hi)
+	}
+
+	var err error
+	for i := hi; i >= lo; i-- {
+		ub, err = ub.RemoveIndex(path, i)
+		if err != nil {
+			return UpdateBuilder{}, err
+		}
+	}
+	return ub, nil
+}
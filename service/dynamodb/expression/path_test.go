@@ -0,0 +1,61 @@
+package expression
+
+import "testing"
+
+func TestPathBuilderBuild(t *testing.T) {
+	nb := Path("root").Field("a").Field("a1").Index(0).Build()
+
+	want := Name("root.a.a1[0]")
+	if nb != want {
+		t.Errorf("Build() = %#v, want %#v", nb, want)
+	}
+}
+
+func TestPathBuilderDoesNotEscapeEmbeddedDots(t *testing.T) {
+	// Build() joins segments with "." and hands the result to
+	// Name(string), so a segment containing a literal "." is not
+	// distinguishable from a path separator — the same limitation
+	// Name(string) itself has. PathBuilder is a typed, less error-prone
+	// way to assemble a path, not an escaping mechanism.
+	nb := Path("root").Field("first.last").Build()
+
+	want := Name("root.first.last")
+	if nb != want {
+		t.Errorf("Build() = %#v, want %#v", nb, want)
+	}
+}
+
+func TestPathBuilderValidateRejectsEmptySegment(t *testing.T) {
+	if err := Path("").validate(); err == nil {
+		t.Error("validate() on a path with an empty root segment should return an error")
+	}
+	if err := Path("a").Field("  ").validate(); err == nil {
+		t.Error("validate() on a path with a blank field segment should return an error")
+	}
+	if err := Path("a").validate(); err != nil {
+		t.Errorf("validate() on a well-formed path returned an error: %v", err)
+	}
+}
+
+func TestRemoveIndexRangeOrdersTargetsHighestIndexFirst(t *testing.T) {
+	ub, err := UpdateBuilder{}.RemoveIndexRange(Path("a").Field("list"), 2, 4)
+	if err != nil {
+		t.Fatalf("RemoveIndexRange returned error: %v", err)
+	}
+
+	ops := ub.operationList[removeOperation]
+	if len(ops) != 3 {
+		t.Fatalf("got %d REMOVE ops, want 3", len(ops))
+	}
+	for i, want := range []string{"a.list[4]", "a.list[3]", "a.list[2]"} {
+		if got := ops[i].name; got != Name(want) {
+			t.Errorf("ops[%d].name = %#v, want %#v", i, got, Name(want))
+		}
+	}
+}
+
+func TestRemoveIndexRangeRejectsBackwardsRange(t *testing.T) {
+	if _, err := (UpdateBuilder{}).RemoveIndexRange(Path("a"), 4, 2); err == nil {
+		t.Error("RemoveIndexRange with hi < lo should return an error")
+	}
+}
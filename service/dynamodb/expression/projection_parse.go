@@ -0,0 +1,34 @@
+package expression
+
+import "fmt"
+
+// ParseProjection reconstructs the ProjectionBuilder that produced the
+// given ProjectionExpression string: a comma-separated list of attribute
+// paths, with #name placeholders resolved against names.
+func ParseProjection(expr string, names map[string]*string) (ProjectionBuilder, error) {
+	p, err := newExprParser(expr, names, nil)
+	if err != nil {
+		return ProjectionBuilder{}, err
+	}
+
+	var paths []NameBuilder
+	for {
+		path, err := p.parsePath()
+		if err != nil {
+			return ProjectionBuilder{}, err
+		}
+		paths = append(paths, path)
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.next()
+	}
+	if p.peek().kind != tokEOF {
+		return ProjectionBuilder{}, fmt.Errorf("parse projection expression error: unexpected trailing token %q", p.peek().lit)
+	}
+	if len(paths) == 0 {
+		return ProjectionBuilder{}, fmt.Errorf("parse projection expression error: expression has no paths")
+	}
+
+	return NamesList(paths[0], paths[1:]...), nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 )
 
 // operationMode specifies the types of update operations that the
@@ -396,66 +398,143 @@ func buildChildNodes(operationBuilderList []operationBuilder) (exprNode, error)
 
 	return node, nil
 }
-// parseExprStr converts an expression string to an exprNode.
-// Since each expression type has unique characteristics for how the are
-// constructed, each builder struct must have a parseExprStr method
-// defined.
-func (ub *UpdateBuilder) parseExprStr(input dynamodb.UpdateItemInput) {
-	opExprsMap := map[operationMode][]string{}
-	remaining := *input.UpdateExpression
-	for len(remaining) > 0 {
-		opMode, opExprs, remainder := firstOp(remaining)
-		opExprsMap[opMode] = opExprs
-		remaining = remainder
+// ParseUpdate reconstructs the UpdateBuilder that produced the given
+// UpdateItemInput's UpdateExpression, resolving every #name and :value
+// placeholder against ExpressionAttributeNames and
+// ExpressionAttributeValues. It is the inverse of UpdateBuilder.Build():
+// an UpdateBuilder round-tripped through a Builder's Build() and then
+// ParseUpdate() produces an equivalent UpdateBuilder.
+//
+// Example:
+//
+//     // input is the UpdateItemInput produced by building
+//     // expression.Set(expression.Name("a"), expression.Value(5))
+//     update, err := expression.ParseUpdate(input)
+func ParseUpdate(input *dynamodb.UpdateItemInput) (UpdateBuilder, error) {
+	if input == nil || input.UpdateExpression == nil {
+		return UpdateBuilder{}, newUnsetParameterError("ParseUpdate", "UpdateItemInput")
 	}
-	for k, v := range opExprsMap {
-		ub.operationList[k] = []operationBuilder{}
-		for _, expr := range v {
-			ub.operationList[k] = append(ub.operationList[k], opBuilderFromExpr(
-				k, expr, input.ExpressionAttributeNames, input.ExpressionAttributeValues),
-			)
-		}
+	p, err := newExprParser(*input.UpdateExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return UpdateBuilder{}, err
+	}
+	ub, err := p.parseUpdate()
+	if err != nil {
+		return UpdateBuilder{}, err
 	}
+	if p.peek().kind != tokEOF {
+		return UpdateBuilder{}, fmt.Errorf("parse update expression error: unexpected trailing token %q", p.peek().lit)
+	}
+	return ub, nil
 }
 
-// firstOp takes the portion of an expression string following the first
-// operation keyword and returns the substring that leads to the next
-// keyword if found. It returns the first operation mode found, its
-// actions, and the string following the first operation.
-// Its purpose is to help break update expressions into there parts.
-// As an example, when parsing the expression "SET x = y, REMOVE z",
-// After extracting "SET", you must find the remained that applies to
-// the set operation.
-// To do so, we split at each of the remaining keywords, leaving just
-// the set operation's actions.
-func firstOp(expr string) (operationMode, []string, string) {
-	expr = strings.Replace(expr, "\n", " ", -1)
-	var opMode operationMode
-	var opCompoundExpr, remainder string
-	candidate := expr
-	for _, v := range updateOps {
-		parts := strings.SplitN(candidate, string(v), 2)
-		if len(parts[0]) == 0 {
-			opMode = v
-			remainder = parts[1]
-			break
+// parseUpdate drives the top-level update expression grammar:
+//
+//     update-expression ::= clause+
+//     clause            ::= ("SET" | "REMOVE" | "ADD" | "DELETE") action ("," action)*
+//
+// Each action is delegated to parseUpdateAction, which knows how to build
+// the operationBuilder appropriate for the enclosing clause's mode.
+func (p *exprParser) parseUpdate() (UpdateBuilder, error) {
+	ub := UpdateBuilder{}
+	if p.peek().kind == tokEOF {
+		return UpdateBuilder{}, fmt.Errorf("parse update expression error: empty update expression")
+	}
+	for p.peek().kind != tokEOF {
+		modeTok := p.next()
+		mode, ok := updateModeFromKeyword(modeTok.lit)
+		if !ok {
+			return UpdateBuilder{}, fmt.Errorf("parse update expression error: expected SET, REMOVE, ADD, or DELETE, got %q", modeTok.lit)
+		}
+
+		if ub.operationList == nil {
+			ub.operationList = map[operationMode][]operationBuilder{}
 		}
-		candidate = parts[0]
-		if len(parts) > 1 {
-			remainder = string(v) + parts[1]
+		for {
+			op, err := p.parseUpdateAction(mode)
+			if err != nil {
+				return UpdateBuilder{}, err
+			}
+			ub.operationList[mode] = append(ub.operationList[mode], op)
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
 		}
 	}
-	opCompoundExpr = remainder
-	for _, v := range updateOps {
-		if v != opMode {
-			opCompoundExpr = strings.SplitN(opCompoundExpr, string(v), 2)[0]
+	return ub, nil
+}
+
+// updateModeFromKeyword maps the clause keyword tokenize produced back to
+// the operationMode it represents.
+func updateModeFromKeyword(kw string) (operationMode, bool) {
+	switch kw {
+	case "SET":
+		return setOperation, true
+	case "REMOVE":
+		return removeOperation, true
+	case "ADD":
+		return addOperation, true
+	case "DELETE":
+		return deleteOperation, true
+	}
+	return "", false
+}
+
+// parseUpdateAction parses a single action within a clause, e.g.
+// "a = if_not_exists(a, :v) + b[0]" within a SET clause, or "d[2]" within
+// a REMOVE clause, into the operationBuilder the enclosing clause's mode
+// requires.
+func (p *exprParser) parseUpdateAction(mode operationMode) (operationBuilder, error) {
+	name, err := p.parsePath()
+	if err != nil {
+		return operationBuilder{}, err
+	}
+
+	if mode == removeOperation {
+		return operationBuilder{name: name, mode: mode}, nil
+	}
+
+	if mode == setOperation {
+		if _, err := p.expect(tokOperator, "="); err != nil {
+			return operationBuilder{}, err
+		}
+		value, err := p.parseSetValue()
+		if err != nil {
+			return operationBuilder{}, err
 		}
+		return operationBuilder{name: name, value: value, mode: mode}, nil
 	}
-	remainder = strings.Trim(remainder[len(opCompoundExpr):], ", ")
-	opCompoundExpr = strings.Trim(opCompoundExpr, ", ")
-	opExprs := strings.Split(opCompoundExpr, ",")
-	for i, v := range opExprs {
-		opExprs[i] = strings.Trim(v, " ")
+
+	// ADD and DELETE both take a bare value placeholder following the path.
+	value, err := p.parseOperand()
+	if err != nil {
+		return operationBuilder{}, err
+	}
+	return operationBuilder{name: name, value: value, mode: mode}, nil
+}
+
+// parseSetValue parses the right-hand side of a SET assignment, which may
+// be a bare operand or two operands joined by "+" or "-", e.g.
+// "if_not_exists(a, :v) + b[0]".
+func (p *exprParser) parseSetValue() (OperandBuilder, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokOperator || (p.peek().lit != "+" && p.peek().lit != "-") {
+		return left, nil
+	}
+	op := p.next().lit
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if op == "+" {
+		return Plus(left, right), nil
 	}
-	return opMode, opExprs, remainder
+	return Minus(left, right), nil
 }
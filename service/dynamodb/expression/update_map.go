@@ -0,0 +1,136 @@
+package expression
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// SetMap adds a Set operation to ub for every entry in m, keyed by
+// attribute name. Entries are applied in sorted key order so that the
+// resulting SET clause, and therefore the UpdateExpression string, is
+// stable across runs.
+func (ub UpdateBuilder) SetMap(m map[string]OperandBuilder) UpdateBuilder {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		ub = ub.Set(Name(name), m[name])
+	}
+	return ub
+}
+
+// AddMap adds an Add operation to ub for every entry in m, keyed by
+// attribute name, in sorted key order.
+func (ub UpdateBuilder) AddMap(m map[string]ValueBuilder) UpdateBuilder {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		ub = ub.Add(Name(name), m[name])
+	}
+	return ub
+}
+
+// DeleteMap adds a Delete operation to ub for every entry in m, keyed by
+// attribute name, in sorted key order.
+func (ub UpdateBuilder) DeleteMap(m map[string]ValueBuilder) UpdateBuilder {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		ub = ub.Delete(Name(name), m[name])
+	}
+	return ub
+}
+
+// RemoveAll adds a Remove operation to ub for each of names, in the order
+// given.
+func (ub UpdateBuilder) RemoveAll(names ...NameBuilder) UpdateBuilder {
+	for _, name := range names {
+		ub = ub.Remove(name)
+	}
+	return ub
+}
+
+// marshalOptions configures SetStruct.
+type marshalOptions struct {
+	omitZeroValues bool
+}
+
+// MarshalOption configures how SetStruct marshals a struct into Set
+// operations.
+type MarshalOption func(*marshalOptions)
+
+// OmitZeroValues causes SetStruct to skip any field that dynamodbattribute
+// marshaled to a NULL AttributeValue, in addition to whatever fields a
+// `dynamodbav:",omitempty"` tag already excluded.
+func OmitZeroValues() MarshalOption {
+	return func(o *marshalOptions) {
+		o.omitZeroValues = true
+	}
+}
+
+// SetStruct marshals v, a struct or pointer to struct, with
+// dynamodbattribute and adds a Set operation for each field present in
+// the result, keyed by its dynamodbav tag name (or field name), in
+// sorted key order. Fields tagged `dynamodbav:",omitempty"` that are
+// empty are already skipped by dynamodbattribute; pass OmitZeroValues to
+// additionally skip any field that marshaled to a NULL AttributeValue.
+//
+// Each field is decoded with UseNumber set so that N attribute values
+// come back as dynamodbattribute.Number rather than float64; decoding straight to
+// float64 would silently lose precision for int64 fields outside the
+// float64-safe integer range (ids, nanosecond timestamps, and the like)
+// by the time they were re-marshaled into the SET clause.
+//
+// SetStruct replaces the chain of Set() calls a direct struct update
+// would otherwise require:
+//
+//     update, err := expression.UpdateBuilder{}.SetStruct(item)
+func (ub UpdateBuilder) SetStruct(v interface{}, opts ...MarshalOption) (UpdateBuilder, error) {
+	var o marshalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	fields, err := dynamodbattribute.MarshalMap(v)
+	if err != nil {
+		return UpdateBuilder{}, fmt.Errorf("SetStruct error: marshal struct: %v", err)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	decoder := dynamodbattribute.NewDecoder(func(d *dynamodbattribute.Decoder) {
+		d.UseNumber = true
+	})
+
+	for _, name := range keys {
+		av := fields[name]
+		if o.omitZeroValues && av.NULL != nil && *av.NULL {
+			continue
+		}
+
+		var value interface{}
+		if err := decoder.Decode(av, &value); err != nil {
+			return UpdateBuilder{}, fmt.Errorf("SetStruct error: decode field %q: %v", name, err)
+		}
+		ub = ub.Set(Name(name), Value(value))
+	}
+
+	return ub, nil
+}
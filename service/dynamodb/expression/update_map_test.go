@@ -0,0 +1,118 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+func TestSetMapAppliesEntriesInSortedKeyOrder(t *testing.T) {
+	ub := UpdateBuilder{}.SetMap(map[string]OperandBuilder{
+		"c": Value(3),
+		"a": Value(1),
+		"b": Value(2),
+	})
+
+	ops := ub.operationList[setOperation]
+	if len(ops) != 3 {
+		t.Fatalf("got %d SET ops, want 3", len(ops))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if got := ops[i].name; got != Name(want) {
+			t.Errorf("ops[%d] = %#v, want %#v", i, got, Name(want))
+		}
+	}
+}
+
+func TestAddMapAndDeleteMapApplyEntriesInSortedKeyOrder(t *testing.T) {
+	add := UpdateBuilder{}.AddMap(map[string]ValueBuilder{
+		"z": Value(1),
+		"m": Value(2),
+	})
+	addOps := add.operationList[addOperation]
+	if len(addOps) != 2 || addOps[0].name != Name("m") || addOps[1].name != Name("z") {
+		t.Fatalf("AddMap did not apply entries in sorted key order: %#v", addOps)
+	}
+
+	del := UpdateBuilder{}.DeleteMap(map[string]ValueBuilder{
+		"z": Value(1),
+		"m": Value(2),
+	})
+	delOps := del.operationList[deleteOperation]
+	if len(delOps) != 2 || delOps[0].name != Name("m") || delOps[1].name != Name("z") {
+		t.Fatalf("DeleteMap did not apply entries in sorted key order: %#v", delOps)
+	}
+}
+
+func TestRemoveAllPreservesGivenOrder(t *testing.T) {
+	ub := UpdateBuilder{}.RemoveAll(Name("b"), Name("a"))
+
+	ops := ub.operationList[removeOperation]
+	if len(ops) != 2 {
+		t.Fatalf("got %d REMOVE ops, want 2", len(ops))
+	}
+	if ops[0].name != Name("b") || ops[1].name != Name("a") {
+		t.Errorf("RemoveAll reordered its arguments: %#v", ops)
+	}
+}
+
+func TestSetStructProducesOneSetOpPerField(t *testing.T) {
+	type item struct {
+		ID   int64  `dynamodbav:"id"`
+		Name string `dynamodbav:"name"`
+	}
+
+	ub, err := UpdateBuilder{}.SetStruct(item{ID: 1, Name: "a"})
+	if err != nil {
+		t.Fatalf("SetStruct returned error: %v", err)
+	}
+
+	ops := ub.operationList[setOperation]
+	if len(ops) != 2 {
+		t.Fatalf("got %d SET ops, want 2", len(ops))
+	}
+	if ops[0].name != Name("id") || ops[1].name != Name("name") {
+		t.Errorf("SetStruct did not apply fields in sorted key order: %#v", ops)
+	}
+}
+
+// TestSetStructDecoderPreservesLargeIntegerPrecision exercises the same
+// MarshalMap-then-decode path SetStruct uses, confirming a
+// dynamodbattribute.Decoder with UseNumber set keeps an int64 outside the
+// float64-safe integer range intact, unlike decoding straight into a bare
+// interface{} with the package-level Unmarshal.
+func TestSetStructDecoderPreservesLargeIntegerPrecision(t *testing.T) {
+	type item struct {
+		ID int64 `dynamodbav:"id"`
+	}
+
+	// 1<<62 + 1 is well outside the range an int64 can round-trip through
+	// float64 without losing precision: float64 rounds it to 1<<62.
+	const bigID int64 = 1<<62 + 1
+
+	fields, err := dynamodbattribute.MarshalMap(item{ID: bigID})
+	if err != nil {
+		t.Fatalf("MarshalMap returned error: %v", err)
+	}
+
+	decoder := dynamodbattribute.NewDecoder(func(d *dynamodbattribute.Decoder) {
+		d.UseNumber = true
+	})
+
+	var got interface{}
+	if err := decoder.Decode(fields["id"], &got); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	num, ok := got.(dynamodbattribute.Number)
+	if !ok {
+		t.Fatalf("decoded field is %T, want dynamodbattribute.Number", got)
+	}
+	gotID, err := num.Int64()
+	if err != nil {
+		t.Fatalf("dynamodbattribute.Number.Int64() returned error: %v", err)
+	}
+	if gotID != bigID {
+		t.Errorf("decoded id as %d, want %d", gotID, bigID)
+	}
+}
@@ -0,0 +1,82 @@
+package expression
+
+import "sort"
+
+// OperationMode identifies which of the four update clauses (SET,
+// REMOVE, ADD, DELETE) an UpdateOp belongs to. It shares its underlying
+// values with the package-private operationMode so that converting
+// between an UpdateOp and the operationBuilder it wraps is lossless.
+type OperationMode string
+
+// The OperationMode values Walk's callback will see, one per update
+// clause.
+const (
+	ModeSet    OperationMode = OperationMode(setOperation)
+	ModeRemove OperationMode = OperationMode(removeOperation)
+	ModeAdd    OperationMode = OperationMode(addOperation)
+	ModeDelete OperationMode = OperationMode(deleteOperation)
+)
+
+// UpdateOp is a stable, exported view of a single update action (one
+// item out of a SET/REMOVE/ADD/DELETE clause) for use with Walk. Value is
+// nil for REMOVE operations, which only carry a path.
+type UpdateOp struct {
+	Mode  OperationMode
+	Name  NameBuilder
+	Value OperandBuilder
+}
+
+func (ob operationBuilder) toUpdateOp() UpdateOp {
+	return UpdateOp{
+		Mode:  OperationMode(ob.mode),
+		Name:  ob.name,
+		Value: ob.value,
+	}
+}
+
+func (op UpdateOp) toOperationBuilder() operationBuilder {
+	return operationBuilder{
+		name:  op.Name,
+		value: op.Value,
+		mode:  operationMode(op.Mode),
+	}
+}
+
+// Walk traverses every operation in ub in clause order (ADD, then
+// DELETE, then REMOVE, then SET — the same ascending alphabetical order
+// buildTree sorts clauses into for Build()) and insertion order within
+// each clause, invoking fn on each one and
+// replacing it with fn's return value. fn may leave Mode unchanged to
+// edit an operation in place, or return a different Mode to move the
+// operation into a different clause.
+//
+// Concrete uses include renaming an attribute path across an entire
+// update expression, rewriting every Value() (e.g. lowercasing strings),
+// and splicing an IfNotExists(...) guard around every SET value:
+//
+//     guarded := expression.Walk(update, func(op expression.UpdateOp) expression.UpdateOp {
+//         if op.Mode == expression.ModeSet {
+//             op.Value = expression.IfNotExists(op.Name, op.Value)
+//         }
+//         return op
+//     })
+func Walk(ub UpdateBuilder, fn func(op UpdateOp) UpdateOp) UpdateBuilder {
+	if ub.operationList == nil {
+		return ub
+	}
+
+	modes := modeList{}
+	for mode := range ub.operationList {
+		modes = append(modes, mode)
+	}
+	sort.Sort(modes)
+
+	walked := UpdateBuilder{operationList: map[operationMode][]operationBuilder{}}
+	for _, mode := range modes {
+		for _, ob := range ub.operationList[mode] {
+			newOb := fn(ob.toUpdateOp()).toOperationBuilder()
+			walked.operationList[newOb.mode] = append(walked.operationList[newOb.mode], newOb)
+		}
+	}
+	return walked
+}
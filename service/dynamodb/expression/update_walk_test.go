@@ -0,0 +1,59 @@
+package expression
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWalkRenamesEveryOperation(t *testing.T) {
+	ub := UpdateBuilder{}.
+		Remove(Name("old.a")).
+		Remove(Name("old.b")).
+		Set(Name("old.c"), nil)
+
+	renamed := Walk(ub, func(op UpdateOp) UpdateOp {
+		op.Name = Name(strings.Replace(op.Name.name, "old.", "new.", 1))
+		return op
+	})
+
+	removeOps := renamed.operationList[removeOperation]
+	if len(removeOps) != 2 {
+		t.Fatalf("got %d REMOVE ops, want 2", len(removeOps))
+	}
+	for i, want := range []string{"new.a", "new.b"} {
+		if got := removeOps[i].name; got != Name(want) {
+			t.Errorf("removeOps[%d].name = %#v, want %#v", i, got, Name(want))
+		}
+	}
+
+	setOps := renamed.operationList[setOperation]
+	if len(setOps) != 1 {
+		t.Fatalf("got %d SET ops, want 1", len(setOps))
+	}
+	if got := setOps[0].name; got != Name("new.c") {
+		t.Errorf("setOps[0].name = %#v, want %#v", got, Name("new.c"))
+	}
+}
+
+func TestWalkCanMoveAnOperationBetweenClauses(t *testing.T) {
+	ub := UpdateBuilder{}.Remove(Name("a"))
+
+	moved := Walk(ub, func(op UpdateOp) UpdateOp {
+		op.Mode = ModeSet
+		op.Value = nil
+		return op
+	})
+
+	if len(moved.operationList[removeOperation]) != 0 {
+		t.Fatalf("REMOVE clause should be empty after moving its only op, got %d", len(moved.operationList[removeOperation]))
+	}
+	if len(moved.operationList[setOperation]) != 1 {
+		t.Fatalf("SET clause should contain the moved op, got %d", len(moved.operationList[setOperation]))
+	}
+}
+
+func TestWalkOnEmptyUpdateBuilder(t *testing.T) {
+	if got := Walk(UpdateBuilder{}, func(op UpdateOp) UpdateOp { return op }); got.operationList != nil {
+		t.Errorf("Walk on a zero-value UpdateBuilder should return it unchanged, got %#v", got)
+	}
+}